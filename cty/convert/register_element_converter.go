@@ -0,0 +1,22 @@
+package convert
+
+import (
+	"github.com/apparentlymart/go-cty/cty"
+)
+
+// init wires this package's Convert function into cty's OfType family of
+// constructors (cty.ListValOfType, cty.MapValOfType, cty.SetValOfType, and
+// their Try variants), so that they can convert elements to a requested
+// type rather than only accepting exact type matches.
+//
+// cty can't import this package directly to get the same effect, since
+// this package necessarily imports cty; RegisterConverter exists
+// specifically to let this package install itself the other way around.
+// A program that never imports cty/convert, even just for this side
+// effect, will find the OfType constructors fall back to requiring exact
+// type matches.
+func init() {
+	cty.RegisterConverter(func(val cty.Value, wantType cty.Type) (cty.Value, error) {
+		return Convert(val, wantType)
+	})
+}
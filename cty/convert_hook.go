@@ -0,0 +1,45 @@
+package cty
+
+import "fmt"
+
+// elementConverter, when non-nil, is used by the OfType family of
+// constructors (ListValOfType, MapValOfType, SetValOfType and their Try
+// variants) to convert an element to the requested element type when it
+// isn't already of that type.
+//
+// It is nil by default, since the conversion rules themselves live in
+// the convert subpackage: this package can't import that one without
+// introducing an import cycle, since the convert subpackage necessarily
+// imports this one. Instead, the convert subpackage's init function calls
+// RegisterConverter to install itself here. Callers who need the OfType
+// constructors to perform real type conversion, rather than only accepting
+// exact type matches, must import cty/convert (even if only for its side
+// effect) somewhere in their program.
+var elementConverter func(Value, Type) (Value, error)
+
+// RegisterConverter installs the given function as the converter used by
+// the OfType family of constructors to convert an element to a requested
+// element type.
+//
+// This is called by cty/convert's init function and is not intended to be
+// called by any other code.
+func RegisterConverter(f func(Value, Type) (Value, error)) {
+	elementConverter = f
+}
+
+// convertElement returns val unchanged if it is already of type ety or of
+// the dynamic pseudo-type. Otherwise, it delegates to the converter
+// installed by RegisterConverter, if any, and fails with an error if no
+// converter is available or if the installed converter rejects the value.
+func convertElement(val Value, ety Type) (Value, error) {
+	if val.ty == DynamicPseudoType || val.ty.Equals(ety) {
+		return val, nil
+	}
+	if elementConverter == nil {
+		return NilVal, fmt.Errorf(
+			"%#v required, but have %#v (import cty/convert to enable automatic conversion)",
+			ety, val.ty,
+		)
+	}
+	return elementConverter(val, ety)
+}
@@ -0,0 +1,50 @@
+package cty
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestOfTypeConstructorsUseRegisteredConverter simulates what cty/convert's
+// init function does in the full build (calling RegisterConverter with its
+// real Convert function), to verify that the OfType family of constructors
+// actually performs conversion once a converter is registered, rather than
+// only accepting exact type matches.
+func TestOfTypeConstructorsUseRegisteredConverter(t *testing.T) {
+	orig := elementConverter
+	defer func() { elementConverter = orig }()
+
+	elementConverter = func(val Value, ety Type) (Value, error) {
+		if val.ty == Number && ety == String {
+			return StringVal(val.AsBigFloat().String()), nil
+		}
+		return NilVal, fmt.Errorf("no conversion from %#v to %#v", val.ty, ety)
+	}
+
+	got, err := TryListValOfType(String, []Value{NumberIntVal(1), NumberIntVal(2)})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := ListVal([]Value{StringVal("1"), StringVal("2")})
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+
+	if _, err := TryMapValOfType(String, map[string]Value{"a": BoolVal(true)}); err == nil {
+		t.Fatal("succeeded converting a value the registered converter rejects; want error")
+	}
+}
+
+// TestOfTypeConstructorsWithoutRegisteredConverter confirms the fallback
+// behavior when no converter has been registered (the state cty is in
+// unless a program imports cty/convert): values must already be of the
+// requested type.
+func TestOfTypeConstructorsWithoutRegisteredConverter(t *testing.T) {
+	orig := elementConverter
+	elementConverter = nil
+	defer func() { elementConverter = orig }()
+
+	if _, err := TryListValOfType(String, []Value{NumberIntVal(1)}); err == nil {
+		t.Fatal("succeeded with a mismatched element type and no registered converter; want error")
+	}
+}
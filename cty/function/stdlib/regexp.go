@@ -3,7 +3,6 @@ package stdlib
 import (
 	"fmt"
 	"regexp"
-	resyntax "regexp/syntax"
 
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
@@ -37,7 +36,7 @@ var RegexFunc = function.New(&function.Spec{
 			return cty.DynamicVal, nil
 		}
 
-		re, err := regexp.Compile(args[0].AsString())
+		re, _, err := compiledPattern(args[0].AsString())
 		if err != nil {
 			// Should never happen, since we checked this in the Type function above.
 			return cty.NilVal, function.NewArgErrorf(0, "error parsing pattern: %s", err)
@@ -85,6 +84,121 @@ var RegexFunc = function.New(&function.Spec{
 	},
 })
 
+var RegexAllFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "pattern",
+			Type: cty.String,
+		},
+		{
+			Name: "string",
+			Type: cty.String,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		if !args[0].IsKnown() {
+			// We can't predict our type without seeing our pattern
+			return cty.DynamicPseudoType, nil
+		}
+
+		retTy, err := regexPatternResultType(args[0].AsString())
+		if err != nil {
+			return cty.DynamicPseudoType, function.NewArgError(0, err)
+		}
+		return cty.List(retTy), nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if retType == cty.DynamicPseudoType {
+			return cty.DynamicVal, nil
+		}
+		ety := retType.ElementType()
+
+		re, _, err := compiledPattern(args[0].AsString())
+		if err != nil {
+			// Should never happen, since we checked this in the Type function above.
+			return cty.NilVal, function.NewArgErrorf(0, "error parsing pattern: %s", err)
+		}
+		str := args[1].AsString()
+
+		allCaptureIdxs := re.FindAllStringSubmatchIndex(str, -1)
+		if len(allCaptureIdxs) == 0 {
+			return cty.ListValEmpty(ety), nil
+		}
+
+		if ety == cty.String {
+			vals := make([]cty.Value, len(allCaptureIdxs))
+			for i, captureIdxs := range allCaptureIdxs {
+				start, end := captureIdxs[0], captureIdxs[1]
+				vals[i] = cty.StringVal(str[start:end])
+			}
+			return cty.ListVal(vals), nil
+		}
+
+		vals := make([]cty.Value, len(allCaptureIdxs))
+		for i, captureIdxs := range allCaptureIdxs {
+			switch {
+			case ety.IsTupleType():
+				captureIdxs = captureIdxs[2:] // index 0 is the whole pattern span, which we ignore by skipping one pair
+				elems := make([]cty.Value, len(captureIdxs)/2)
+				for j := range elems {
+					start, end := captureIdxs[j*2], captureIdxs[j*2+1]
+					if start < 0 || end < 0 {
+						elems[j] = cty.NullVal(cty.String) // Did not match anything because containing group didn't match
+						continue
+					}
+					elems[j] = cty.StringVal(str[start:end])
+				}
+				vals[i] = cty.TupleVal(elems)
+			case ety.IsObjectType():
+				captureIdxs = captureIdxs[2:] // index 0 is the whole pattern span, which we ignore by skipping one pair
+				elems := make(map[string]cty.Value, len(captureIdxs)/2)
+				names := re.SubexpNames()[1:]
+				for j, name := range names {
+					start, end := captureIdxs[j*2], captureIdxs[j*2+1]
+					if start < 0 || end < 0 {
+						elems[name] = cty.NullVal(cty.String) // Did not match anything because containing group didn't match
+						continue
+					}
+					elems[name] = cty.StringVal(str[start:end])
+				}
+				vals[i] = cty.ObjectVal(elems)
+			default:
+				// Should never happen
+				return cty.NilVal, fmt.Errorf("invalid return type: %s", retType.FriendlyNameForConstraint())
+			}
+		}
+		return cty.ListVal(vals), nil
+	},
+})
+
+var RegexReplaceFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "pattern",
+			Type: cty.String,
+		},
+		{
+			Name: "string",
+			Type: cty.String,
+		},
+		{
+			Name: "replacement",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		re, _, err := compiledPattern(args[0].AsString())
+		if err != nil {
+			return cty.NilVal, function.NewArgErrorf(0, "error parsing pattern: %s", err)
+		}
+		str := args[1].AsString()
+		replacement := args[2].AsString()
+
+		return cty.StringVal(re.ReplaceAllString(str, replacement)), nil
+	},
+})
+
 // Regex is a function that extracts one or more substrings from a given
 // string by applying a regular expression pattern.
 //
@@ -107,23 +221,46 @@ func Regex(pattern, str cty.Value) (cty.Value, error) {
 	return RegexFunc.Call([]cty.Value{pattern, str})
 }
 
+// RegexAll is a function that extracts zero or more substrings from a given
+// string by applying a regular expression pattern, returning a list of
+// matches.
+//
+// Each element of the result follows the same type selection rules as
+// Regex, applied once per non-overlapping match found in the string.
+func RegexAll(pattern, str cty.Value) (cty.Value, error) {
+	return RegexAllFunc.Call([]cty.Value{pattern, str})
+}
+
+// RegexReplace is a function that replaces all occurrences of a given
+// regular expression pattern in a string with a replacement string, which
+// may include references to subgroups of the pattern using the
+// "$1" (for an unnamed group) or "${name}" (for a named group) syntax
+// supported by regexp.Expand.
+func RegexReplace(pattern, str, replacement cty.Value) (cty.Value, error) {
+	return RegexReplaceFunc.Call([]cty.Value{pattern, str, replacement})
+}
+
 // regexPatternResultType parses the given regular expression pattern and
 // returns the structural type that would be returned to represent its
 // capture groups.
 //
 // Returns an error if parsing fails or if the pattern uses a mixture of
 // named and unnamed capture groups, which is not permitted.
+//
+// The underlying compilation and analysis work is shared with the Impl
+// phase via the shared regex cache, so calling this repeatedly with the
+// same pattern is cheap.
 func regexPatternResultType(pattern string) (cty.Type, error) {
-	re, rawErr := regexp.Compile(pattern)
-	switch err := rawErr.(type) {
-	case *resyntax.Error:
-		return cty.NilType, fmt.Errorf("invalid regexp pattern: %s in %s", err.Code, err.Expr)
-	case error:
-		// Should never happen, since all regexp compile errors should
-		// be resyntax.Error, but just in case...
-		return cty.NilType, fmt.Errorf("error parsing pattern: %s", err)
-	}
+	_, ty, err := compiledPattern(pattern)
+	return ty, err
+}
 
+// regexPatternResultTypeForRegexp derives the structural result type for an
+// already-compiled pattern. It contains the logic previously inlined in
+// regexPatternResultType, split out so that compiledPattern can derive the
+// type only once per distinct pattern and cache it alongside the compiled
+// regexp.
+func regexPatternResultTypeForRegexp(re *regexp.Regexp) (cty.Type, error) {
 	allNames := re.SubexpNames()[1:]
 	var names []string
 	unnamed := 0
@@ -160,4 +297,4 @@ func regexPatternResultType(pattern string) (cty.Type, error) {
 		}
 		return cty.Object(atys), nil
 	}
-}
\ No newline at end of file
+}
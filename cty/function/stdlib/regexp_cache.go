@@ -0,0 +1,141 @@
+package stdlib
+
+import (
+	"container/list"
+	"fmt"
+	"regexp"
+	resyntax "regexp/syntax"
+	"sync"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// defaultRegexCacheSize is the number of compiled patterns the shared regex
+// cache retains by default. It was chosen to comfortably cover the set of
+// distinct patterns used by a typical Terraform configuration or HCL
+// document without growing unbounded for pathological inputs that construct
+// patterns dynamically.
+const defaultRegexCacheSize = 256
+
+// regexCacheEntry bundles the artifacts derived from compiling a pattern:
+// the compiled regexp itself, used during Impl, and the result type derived
+// from its capture groups, used during Type. Keeping them together means a
+// single cache lookup serves both phases of evaluating Regex, RegexAll, and
+// RegexReplace.
+type regexCacheEntry struct {
+	pattern string
+	re      *regexp.Regexp
+	ty      cty.Type
+}
+
+// regexCache is a size-bounded least-recently-used cache of compiled
+// patterns. It holds only pattern strings, compiled regexps, and derived
+// types, so it never retains a reference to caller-supplied cty.Value data.
+type regexCache struct {
+	mu      sync.RWMutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // most-recently-used entry at the front
+}
+
+func newRegexCache(size int) *regexCache {
+	return &regexCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+var sharedRegexCache = newRegexCache(defaultRegexCacheSize)
+
+// SetRegexCacheSize sets the maximum number of compiled regular expression
+// patterns that RegexFunc, RegexAllFunc, and RegexReplaceFunc will retain
+// between calls. Passing zero disables the cache entirely, forcing every
+// call to recompile its pattern.
+//
+// This is safe to call concurrently with regex function evaluation. Shrinking
+// the size evicts the least-recently-used entries down to the new limit
+// immediately, before this function returns.
+func SetRegexCacheSize(n int) {
+	sharedRegexCache.setSize(n)
+}
+
+func (c *regexCache) setSize(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.size = n
+	c.evictLocked()
+}
+
+func (c *regexCache) evictLocked() {
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		entry := oldest.Value.(*regexCacheEntry)
+		c.order.Remove(oldest)
+		delete(c.entries, entry.pattern)
+	}
+}
+
+func (c *regexCache) get(pattern string) (*regexCacheEntry, bool) {
+	c.mu.RLock()
+	elem, ok := c.entries[pattern]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	c.order.MoveToFront(elem)
+	c.mu.Unlock()
+
+	return elem.Value.(*regexCacheEntry), true
+}
+
+func (c *regexCache) put(entry *regexCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size <= 0 {
+		return
+	}
+	if elem, ok := c.entries[entry.pattern]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(entry)
+	c.entries[entry.pattern] = elem
+	c.evictLocked()
+}
+
+// compiledPattern returns the compiled regexp and derived cty.Type for the
+// given pattern string, consulting the shared regex cache so that repeated
+// evaluations of the same pattern, across both the Type and Impl phases of
+// a function call, only pay the cost of compiling and analyzing it once.
+func compiledPattern(pattern string) (*regexp.Regexp, cty.Type, error) {
+	if entry, ok := sharedRegexCache.get(pattern); ok {
+		return entry.re, entry.ty, nil
+	}
+
+	re, rawErr := regexp.Compile(pattern)
+	switch err := rawErr.(type) {
+	case *resyntax.Error:
+		return nil, cty.NilType, fmt.Errorf("invalid regexp pattern: %s in %s", err.Code, err.Expr)
+	case error:
+		// Should never happen, since all regexp compile errors should
+		// be resyntax.Error, but just in case...
+		return nil, cty.NilType, fmt.Errorf("error parsing pattern: %s", err)
+	}
+
+	ty, err := regexPatternResultTypeForRegexp(re)
+	if err != nil {
+		return nil, cty.NilType, err
+	}
+
+	sharedRegexCache.put(&regexCacheEntry{pattern: pattern, re: re, ty: ty})
+	return re, ty, nil
+}
@@ -0,0 +1,85 @@
+package stdlib
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestRegexCacheGetPut(t *testing.T) {
+	c := newRegexCache(2)
+
+	if _, ok := c.get("a"); ok {
+		t.Fatalf("unexpected hit in an empty cache")
+	}
+
+	aRe := regexp.MustCompile("a")
+	c.put(&regexCacheEntry{pattern: "a", re: aRe, ty: cty.String})
+
+	entry, ok := c.get("a")
+	if !ok {
+		t.Fatalf("expected a cache hit for \"a\"")
+	}
+	if entry.re != aRe {
+		t.Errorf("got a different *regexp.Regexp back than was stored")
+	}
+}
+
+func TestRegexCacheEviction(t *testing.T) {
+	c := newRegexCache(2)
+
+	c.put(&regexCacheEntry{pattern: "a", re: regexp.MustCompile("a"), ty: cty.String})
+	c.put(&regexCacheEntry{pattern: "b", re: regexp.MustCompile("b"), ty: cty.String})
+
+	// "a" is now the least-recently-used entry; adding a third pattern
+	// should evict it rather than "b".
+	c.put(&regexCacheEntry{pattern: "c", re: regexp.MustCompile("c"), ty: cty.String})
+
+	if _, ok := c.get("a"); ok {
+		t.Errorf("pattern \"a\" was not evicted once the cache exceeded its size")
+	}
+	if _, ok := c.get("b"); !ok {
+		t.Errorf("pattern \"b\" was evicted, but \"a\" should have been evicted first")
+	}
+}
+
+func TestRegexCacheSetSizeEvictsImmediately(t *testing.T) {
+	c := newRegexCache(2)
+
+	c.put(&regexCacheEntry{pattern: "a", re: regexp.MustCompile("a"), ty: cty.String})
+	c.put(&regexCacheEntry{pattern: "b", re: regexp.MustCompile("b"), ty: cty.String})
+
+	c.setSize(1)
+
+	if got, want := c.order.Len(), 1; got != want {
+		t.Fatalf("wrong number of entries after shrinking cache: got %d, want %d", got, want)
+	}
+	if _, ok := c.get("a"); ok {
+		t.Errorf("pattern \"a\" should have been evicted immediately by setSize, not lazily")
+	}
+}
+
+func TestCompiledPatternCaching(t *testing.T) {
+	const pattern = `TestCompiledPatternCaching-[a-z]+`
+
+	re1, ty1, err := compiledPattern(pattern)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	re2, ty2, err := compiledPattern(pattern)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if re1 != re2 {
+		t.Errorf("compiledPattern did not return the same *regexp.Regexp for a repeated pattern")
+	}
+	if !ty1.Equals(ty2) {
+		t.Errorf("compiledPattern returned inconsistent types for a repeated pattern")
+	}
+
+	if _, _, err := compiledPattern(`[`); err == nil {
+		t.Fatal("succeeded compiling an invalid pattern; want error")
+	}
+}
@@ -0,0 +1,133 @@
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestRegex(t *testing.T) {
+	tests := []struct {
+		Pattern string
+		String  string
+		Want    cty.Value
+		Err     bool
+	}{
+		{
+			`[a-z]+`,
+			"hello",
+			cty.StringVal("hello"),
+			false,
+		},
+		{
+			`(\w+)@(\w+)`,
+			"foo@bar",
+			cty.TupleVal([]cty.Value{cty.StringVal("foo"), cty.StringVal("bar")}),
+			false,
+		},
+		{
+			`[a-z]+`,
+			"123",
+			cty.NilVal,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Pattern+"/"+test.String, func(t *testing.T) {
+			got, err := Regex(cty.StringVal(test.Pattern), cty.StringVal(test.String))
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestRegexAll(t *testing.T) {
+	tests := []struct {
+		Pattern string
+		String  string
+		Want    cty.Value
+	}{
+		{
+			`[a-z]+`,
+			"hello world",
+			cty.ListVal([]cty.Value{cty.StringVal("hello"), cty.StringVal("world")}),
+		},
+		{
+			// No matches at all: the result must still carry a list type
+			// whose element type matches what the Type callback computed,
+			// even though the pattern has a capture group.
+			`(foo)(bar)`,
+			"no match here",
+			cty.ListValEmpty(cty.Tuple([]cty.Type{cty.String, cty.String})),
+		},
+		{
+			`[0-9]+`,
+			"no digits here",
+			cty.ListValEmpty(cty.String),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Pattern+"/"+test.String, func(t *testing.T) {
+			got, err := RegexAll(cty.StringVal(test.Pattern), cty.StringVal(test.String))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+			if !got.Type().Equals(test.Want.Type()) {
+				t.Errorf("wrong result type\ngot:  %#v\nwant: %#v", got.Type(), test.Want.Type())
+			}
+		})
+	}
+}
+
+func TestRegexReplace(t *testing.T) {
+	tests := []struct {
+		Pattern     string
+		String      string
+		Replacement string
+		Want        cty.Value
+	}{
+		{
+			`[a-z]+`,
+			"hello world",
+			"X",
+			cty.StringVal("X X"),
+		},
+		{
+			`(\w+)@(\w+)`,
+			"foo@bar",
+			"$2@$1",
+			cty.StringVal("bar@foo"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Pattern+"/"+test.String, func(t *testing.T) {
+			got, err := RegexReplace(cty.StringVal(test.Pattern), cty.StringVal(test.String), cty.StringVal(test.Replacement))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
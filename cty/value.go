@@ -0,0 +1,26 @@
+package cty
+
+import (
+	"math/big"
+)
+
+// AsBigFloat returns a big.Float representation of a non-null, non-unknown
+// number value, or panics if the value is not of type Number.
+//
+// For convenience and on the assumption that numbers usually do not exceed
+// the bounds of float64, it's also possible to round-trip a number through
+// the float64 representation with AsBigFloat().Float64(). This may allow
+// further processing with more convenient APIs in the math package, though
+// at the cost of losing precision.
+//
+// The returned value is a copy of the value's internal big.Float, so the
+// caller is free to mutate it without violating the "no mutation after
+// wrapping" contract documented on NumberVal.
+func (val Value) AsBigFloat() *big.Float {
+	if val.ty != Number {
+		panic("not a number")
+	}
+
+	bf := val.v.(*big.Float)
+	return new(big.Float).Copy(bf)
+}
@@ -3,6 +3,7 @@ package cty
 import (
 	"fmt"
 	"math/big"
+	"strings"
 
 	"golang.org/x/text/unicode/norm"
 
@@ -41,6 +42,64 @@ func NumberFloatVal(v float64) Value {
 	return NumberVal(new(big.Float).SetFloat64(v))
 }
 
+// NumberStringVal returns a Value of type Number whose internal value is
+// parsed from the given decimal string.
+//
+// Unlike NumberFloatVal, the string is parsed with a precision that grows
+// with the number of digits in the literal (see numberStringValPrec), so this
+// is the preferred way to construct a Number from a user-supplied decimal
+// literal (such as "0.1") where NumberFloatVal would otherwise force a lossy
+// round-trip through float64. Integer literals are represented exactly; a
+// decimal fraction with no finite binary form, such as "0.1", is still
+// rounded to nearest even at that precision.
+//
+// Returns an error if the string is not a valid decimal number, or if it
+// represents a non-finite value such as "NaN" or "Inf".
+func NumberStringVal(s string) (Value, error) {
+	prec := numberStringValPrec(s)
+	f, _, err := big.ParseFloat(s, 10, prec, big.ToNearestEven)
+	if err != nil {
+		return NilVal, fmt.Errorf("invalid number literal %q: %s", s, err)
+	}
+	if f.IsInf() {
+		return NilVal, fmt.Errorf("invalid number literal %q: value is not finite", s)
+	}
+	return NumberVal(f), nil
+}
+
+// MustNumberStringVal is like NumberStringVal but panics instead of
+// returning an error.
+func MustNumberStringVal(s string) Value {
+	v, err := NumberStringVal(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// numberStringValPrec returns the precision, in bits, that NumberStringVal
+// should parse the given literal with: four bits per decimal digit in its
+// mantissa, which is enough to hold any integer of that many digits exactly,
+// and never less than 64 bits.
+func numberStringValPrec(s string) uint {
+	if idx := strings.IndexAny(s, "eE"); idx >= 0 {
+		s = s[:idx] // the exponent doesn't contribute to precision
+	}
+
+	mantissaDigits := 0
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= '0' && c <= '9' {
+			mantissaDigits++
+		}
+	}
+
+	prec := uint(4 * mantissaDigits)
+	if prec < 64 {
+		prec = 64
+	}
+	return prec
+}
+
 // StringVal returns a Value of type String whose internal value is the
 // given string.
 //
@@ -80,9 +139,26 @@ func ObjectVal(attrs map[string]Value) Value {
 // dynamic pseudo-type) then this function will panic. It will panic also
 // if the given list is empty, since then the element type cannot be inferred.
 // (See also ListValEmpty.)
+//
+// This function delegates to TryListVal and panics on any error it returns,
+// so see TryListVal for a variant of this function that returns an error
+// instead of panicking, and ListValOfType for a variant that accepts an
+// explicit element type and so tolerates an empty slice.
 func ListVal(vals []Value) Value {
+	v, err := TryListVal(vals)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// TryListVal is like ListVal except that it returns an error rather than
+// panicking when the given values are inconsistent or empty, making it
+// suitable for use in long-running programs (providers, language servers)
+// that need to recover gracefully from caller mistakes rather than crash.
+func TryListVal(vals []Value) (Value, error) {
 	if len(vals) == 0 {
-		panic("must not call ListVal with empty slice")
+		return NilVal, fmt.Errorf("cannot construct list value from empty slice; use ListValEmpty or ListValOfType instead")
 	}
 	elementType := DynamicPseudoType
 	rawList := make([]interface{}, len(vals))
@@ -91,10 +167,10 @@ func ListVal(vals []Value) Value {
 		if elementType == DynamicPseudoType {
 			elementType = val.ty
 		} else if val.ty != DynamicPseudoType && !elementType.Equals(val.ty) {
-			panic(fmt.Errorf(
+			return NilVal, fmt.Errorf(
 				"inconsistent list element types (%#v then %#v)",
 				elementType, val.ty,
-			))
+			)
 		}
 
 		rawList[i] = val.v
@@ -103,7 +179,7 @@ func ListVal(vals []Value) Value {
 	return Value{
 		ty: List(elementType),
 		v:  rawList,
-	}
+	}, nil
 }
 
 // ListValEmpty returns an empty list of the given element type.
@@ -114,6 +190,45 @@ func ListValEmpty(element Type) Value {
 	}
 }
 
+// ListValOfType returns a Value of list type whose element type is the
+// given type, converting each of the given values to that type.
+//
+// Unlike ListVal, this function accepts an explicit element type and so
+// tolerates an empty slice of values, returning the same result as
+// ListValEmpty would in that case. This function panics if any value
+// cannot be converted to ety; see TryListValOfType for a variant that
+// returns an error instead.
+//
+// Conversion requires importing cty/convert somewhere in the program (even
+// if only for its side effect); without it, values must already be of ety
+// or of the dynamic pseudo-type.
+func ListValOfType(ety Type, vals []Value) Value {
+	v, err := TryListValOfType(ety, vals)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// TryListValOfType is like ListValOfType except that it returns an error
+// rather than panicking when an element cannot be converted to ety.
+func TryListValOfType(ety Type, vals []Value) (Value, error) {
+	rawList := make([]interface{}, len(vals))
+
+	for i, val := range vals {
+		cv, err := convertElement(val, ety)
+		if err != nil {
+			return NilVal, fmt.Errorf("unsuitable value for list element %d: %s", i, err)
+		}
+		rawList[i] = cv.v
+	}
+
+	return Value{
+		ty: List(ety),
+		v:  rawList,
+	}, nil
+}
+
 // MapVal returns a Value of a map type whose element type is defined by
 // the types of the given values, which must be homogenous.
 //
@@ -121,9 +236,26 @@ func ListValEmpty(element Type) Value {
 // dynamic pseudo-type) then this function will panic. It will panic also
 // if the given map is empty, since then the element type cannot be inferred.
 // (See also MapValEmpty.)
+//
+// This function delegates to TryMapVal and panics on any error it returns,
+// so see TryMapVal for a variant of this function that returns an error
+// instead of panicking, and MapValOfType for a variant that accepts an
+// explicit element type and so tolerates an empty map.
 func MapVal(vals map[string]Value) Value {
+	v, err := TryMapVal(vals)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// TryMapVal is like MapVal except that it returns an error rather than
+// panicking when the given values are inconsistent or empty, making it
+// suitable for use in long-running programs (providers, language servers)
+// that need to recover gracefully from caller mistakes rather than crash.
+func TryMapVal(vals map[string]Value) (Value, error) {
 	if len(vals) == 0 {
-		panic("must not call MapVal with empty map")
+		return NilVal, fmt.Errorf("cannot construct map value from empty map; use MapValEmpty or MapValOfType instead")
 	}
 	elementType := DynamicPseudoType
 	rawMap := make(map[string]interface{}, len(vals))
@@ -132,10 +264,10 @@ func MapVal(vals map[string]Value) Value {
 		if elementType == DynamicPseudoType {
 			elementType = val.ty
 		} else if val.ty != DynamicPseudoType && !elementType.Equals(val.ty) {
-			panic(fmt.Errorf(
+			return NilVal, fmt.Errorf(
 				"inconsistent map element types (%#v then %#v)",
 				elementType, val.ty,
-			))
+			)
 		}
 
 		rawMap[key] = val.v
@@ -144,7 +276,7 @@ func MapVal(vals map[string]Value) Value {
 	return Value{
 		ty: Map(elementType),
 		v:  rawMap,
-	}
+	}, nil
 }
 
 // MapValEmpty returns an empty map of the given element type.
@@ -155,6 +287,45 @@ func MapValEmpty(element Type) Value {
 	}
 }
 
+// MapValOfType returns a Value of map type whose element type is the given
+// type, converting each of the given values to that type.
+//
+// Unlike MapVal, this function accepts an explicit element type and so
+// tolerates an empty map of values, returning the same result as
+// MapValEmpty would in that case. This function panics if any value
+// cannot be converted to ety; see TryMapValOfType for a variant that
+// returns an error instead.
+//
+// Conversion requires importing cty/convert somewhere in the program (even
+// if only for its side effect); without it, values must already be of ety
+// or of the dynamic pseudo-type.
+func MapValOfType(ety Type, vals map[string]Value) Value {
+	v, err := TryMapValOfType(ety, vals)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// TryMapValOfType is like MapValOfType except that it returns an error
+// rather than panicking when an element cannot be converted to ety.
+func TryMapValOfType(ety Type, vals map[string]Value) (Value, error) {
+	rawMap := make(map[string]interface{}, len(vals))
+
+	for key, val := range vals {
+		cv, err := convertElement(val, ety)
+		if err != nil {
+			return NilVal, fmt.Errorf("unsuitable value for map element %q: %s", key, err)
+		}
+		rawMap[key] = cv.v
+	}
+
+	return Value{
+		ty: Map(ety),
+		v:  rawMap,
+	}, nil
+}
+
 // SetVal returns a Value of set type whose element type is defined by
 // the types of the given values, which must be homogenous.
 //
@@ -162,9 +333,26 @@ func MapValEmpty(element Type) Value {
 // dynamic pseudo-type) then this function will panic. It will panic also
 // if the given list is empty, since then the element type cannot be inferred.
 // (See also SetValEmpty.)
+//
+// This function delegates to TrySetVal and panics on any error it returns,
+// so see TrySetVal for a variant of this function that returns an error
+// instead of panicking, and SetValOfType for a variant that accepts an
+// explicit element type and so tolerates an empty slice.
 func SetVal(vals []Value) Value {
+	v, err := TrySetVal(vals)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// TrySetVal is like SetVal except that it returns an error rather than
+// panicking when the given values are inconsistent or empty, making it
+// suitable for use in long-running programs (providers, language servers)
+// that need to recover gracefully from caller mistakes rather than crash.
+func TrySetVal(vals []Value) (Value, error) {
 	if len(vals) == 0 {
-		panic("must not call SetVal with empty slice")
+		return NilVal, fmt.Errorf("cannot construct set value from empty slice; use SetValEmpty or SetValOfType instead")
 	}
 	elementType := DynamicPseudoType
 	rawList := make([]interface{}, len(vals))
@@ -173,10 +361,10 @@ func SetVal(vals []Value) Value {
 		if elementType == DynamicPseudoType {
 			elementType = val.ty
 		} else if val.ty != DynamicPseudoType && !elementType.Equals(val.ty) {
-			panic(fmt.Errorf(
+			return NilVal, fmt.Errorf(
 				"inconsistent set element types (%#v then %#v)",
 				elementType, val.ty,
-			))
+			)
 		}
 
 		rawList[i] = val.v
@@ -187,7 +375,7 @@ func SetVal(vals []Value) Value {
 	return Value{
 		ty: Set(elementType),
 		v:  rawVal,
-	}
+	}, nil
 }
 
 // SetValEmpty returns an empty set of the given element type.
@@ -196,4 +384,45 @@ func SetValEmpty(element Type) Value {
 		ty: Set(element),
 		v:  set.NewSet(setRules{element}),
 	}
-}
\ No newline at end of file
+}
+
+// SetValOfType returns a Value of set type whose element type is the given
+// type, converting each of the given values to that type.
+//
+// Unlike SetVal, this function accepts an explicit element type and so
+// tolerates an empty slice of values, returning the same result as
+// SetValEmpty would in that case. This function panics if any value
+// cannot be converted to ety; see TrySetValOfType for a variant that
+// returns an error instead.
+//
+// Conversion requires importing cty/convert somewhere in the program (even
+// if only for its side effect); without it, values must already be of ety
+// or of the dynamic pseudo-type.
+func SetValOfType(ety Type, vals []Value) Value {
+	v, err := TrySetValOfType(ety, vals)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// TrySetValOfType is like SetValOfType except that it returns an error
+// rather than panicking when an element cannot be converted to ety.
+func TrySetValOfType(ety Type, vals []Value) (Value, error) {
+	rawList := make([]interface{}, len(vals))
+
+	for i, val := range vals {
+		cv, err := convertElement(val, ety)
+		if err != nil {
+			return NilVal, fmt.Errorf("unsuitable value for set element %d: %s", i, err)
+		}
+		rawList[i] = cv.v
+	}
+
+	rawVal := set.NewSetFromSlice(setRules{ety}, rawList)
+
+	return Value{
+		ty: Set(ety),
+		v:  rawVal,
+	}, nil
+}
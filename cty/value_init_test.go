@@ -0,0 +1,100 @@
+package cty
+
+import (
+	"testing"
+)
+
+func TestListValOfType(t *testing.T) {
+	got := ListValOfType(String, nil)
+	want := ListValEmpty(String)
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result for empty slice\ngot:  %#v\nwant: %#v", got, want)
+	}
+
+	got = ListValOfType(String, []Value{StringVal("a"), StringVal("b")})
+	want = ListVal([]Value{StringVal("a"), StringVal("b")})
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestTryListValOfType(t *testing.T) {
+	if _, err := TryListValOfType(String, []Value{NumberIntVal(1)}); err == nil {
+		t.Fatal("succeeded with a mismatched element type; want error")
+	}
+
+	if _, err := TryListValOfType(String, []Value{StringVal("a")}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestMapValOfType(t *testing.T) {
+	got := MapValOfType(String, nil)
+	want := MapValEmpty(String)
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result for empty map\ngot:  %#v\nwant: %#v", got, want)
+	}
+
+	got = MapValOfType(String, map[string]Value{"a": StringVal("x")})
+	want = MapVal(map[string]Value{"a": StringVal("x")})
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestTryMapValOfType(t *testing.T) {
+	if _, err := TryMapValOfType(String, map[string]Value{"a": NumberIntVal(1)}); err == nil {
+		t.Fatal("succeeded with a mismatched element type; want error")
+	}
+}
+
+func TestSetValOfType(t *testing.T) {
+	got := SetValOfType(String, nil)
+	want := SetValEmpty(String)
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result for empty slice\ngot:  %#v\nwant: %#v", got, want)
+	}
+
+	got = SetValOfType(String, []Value{StringVal("a"), StringVal("b")})
+	want = SetVal([]Value{StringVal("a"), StringVal("b")})
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestTrySetValOfType(t *testing.T) {
+	if _, err := TrySetValOfType(String, []Value{NumberIntVal(1)}); err == nil {
+		t.Fatal("succeeded with a mismatched element type; want error")
+	}
+}
+
+func TestTryListVal(t *testing.T) {
+	if _, err := TryListVal(nil); err == nil {
+		t.Fatal("succeeded with an empty slice; want error")
+	}
+
+	if _, err := TryListVal([]Value{StringVal("a"), NumberIntVal(1)}); err == nil {
+		t.Fatal("succeeded with inconsistent element types; want error")
+	}
+
+	got, err := TryListVal([]Value{StringVal("a"), StringVal("b")})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := ListVal([]Value{StringVal("a"), StringVal("b")})
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestTryMapVal(t *testing.T) {
+	if _, err := TryMapVal(nil); err == nil {
+		t.Fatal("succeeded with an empty map; want error")
+	}
+}
+
+func TestTrySetVal(t *testing.T) {
+	if _, err := TrySetVal(nil); err == nil {
+		t.Fatal("succeeded with an empty slice; want error")
+	}
+}
@@ -0,0 +1,94 @@
+package cty
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestNumberStringVal(t *testing.T) {
+	tests := []struct {
+		Input string
+		Err   bool
+	}{
+		{"0", false},
+		{"0.1", false},
+		{"-12345.6789", false},
+		{"1e10", false},
+		{"not a number", true},
+		{"NaN", true},
+		{"Inf", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Input, func(t *testing.T) {
+			got, err := NumberStringVal(test.Input)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.ty != Number {
+				t.Fatalf("result is not of type Number")
+			}
+		})
+	}
+}
+
+func TestNumberStringValExactPrecision(t *testing.T) {
+	// 0.1 cannot be represented exactly as a float64, so NumberFloatVal
+	// and NumberStringVal must disagree here: this is the whole point of
+	// NumberStringVal existing.
+	viaFloat := NumberFloatVal(0.1)
+	viaString, err := NumberStringVal("0.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if viaFloat.RawEquals(viaString) {
+		t.Fatal("NumberFloatVal(0.1) and NumberStringVal(\"0.1\") unexpectedly matched; expected the string form to be more precise")
+	}
+
+	want := new(big.Float).SetPrec(viaString.AsBigFloat().Prec())
+	want.Parse("0.1", 10)
+	if viaString.AsBigFloat().Cmp(want) != 0 {
+		t.Errorf("NumberStringVal(\"0.1\") did not round-trip exactly")
+	}
+}
+
+func TestMustNumberStringVal(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for invalid number string")
+		}
+	}()
+	MustNumberStringVal("not a number")
+}
+
+func TestAsBigFloat(t *testing.T) {
+	v := NumberIntVal(5)
+	bf := v.AsBigFloat()
+	if bf.Cmp(big.NewFloat(5)) != 0 {
+		t.Fatalf("wrong value: got %s, want 5", bf.String())
+	}
+
+	// Mutating the returned *big.Float must not affect the original value,
+	// since AsBigFloat documents that it returns a defensive copy.
+	bf.SetInt64(10)
+	if got := v.AsBigFloat(); got.Cmp(big.NewFloat(5)) != 0 {
+		t.Errorf("mutating the result of AsBigFloat affected the original value: got %s, want 5", got.String())
+	}
+}
+
+func TestAsBigFloatPanicsOnWrongType(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for non-number value")
+		}
+	}()
+	StringVal("hello").AsBigFloat()
+}